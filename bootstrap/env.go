@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
@@ -11,7 +13,13 @@ type Env struct {
 	DBDriver string `mapstructure:"DB_DRIVER"`
 	DBSource string `mapstructure:"DB_SOURCE"`
 
-	MigrationURL string `mapstructure:"MIGRATION_URL"`
+	DBPoolMinConns          int32         `mapstructure:"DB_POOL_MIN_CONNS"`
+	DBPoolMaxConns          int32         `mapstructure:"DB_POOL_MAX_CONNS"`
+	DBPoolHealthCheckPeriod time.Duration `mapstructure:"DB_POOL_HEALTH_CHECK_PERIOD"`
+
+	ServerAddress     string `mapstructure:"SERVER_ADDRESS"`
+	EntryRack         string `mapstructure:"ENTRY_RACK"`
+	WorkerConcurrency int    `mapstructure:"WORKER_CONCURRENCY"`
 }
 
 func NewEnv() *Env {
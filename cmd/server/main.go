@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"app/bootstrap"
+	"app/internal/handler"
+	"app/internal/storage"
+	"app/internal/storage/postgres"
+	"app/internal/worker"
+)
+
+func main() {
+	logrus.SetFormatter(new(logrus.JSONFormatter))
+
+	env := bootstrap.NewEnv()
+	ctx := context.Background()
+
+	tp, err := setupTracing()
+	if err != nil {
+		logrus.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer tp.Shutdown(ctx)
+
+	pool := connectToDB(ctx, env)
+
+	m, err := storage.NewMigrate(stdlib.OpenDBFromPool(pool), env.DBDriver)
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(m, os.Args[2:])
+		return
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		logrus.Fatalf("failed to run migrate up: %v", err)
+	}
+	logrus.Printf("db migrated successfully")
+
+	store := postgres.NewStore(pool)
+
+	concurrency := env.WorkerConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	workerPool := worker.NewPool(pool, store, env.EntryRack, concurrency, 0, 0)
+	workerPool.Run(ctx)
+
+	h := handler.New(store, pool, env.EntryRack)
+	mux := http.NewServeMux()
+	h.Routes(mux)
+	mux.Handle("GET /metrics", promhttp.Handler())
+
+	logrus.Infof("listening on %s", env.ServerAddress)
+	if err := http.ListenAndServe(env.ServerAddress, mux); err != nil {
+		logrus.Fatalf("server stopped: %v", err)
+	}
+}
+
+// setupTracing registers the process-wide TracerProvider that every
+// tracer.Start call in the service (picking, postgres) exports through.
+// Without it those spans are no-ops: otel.Tracer reads whatever provider
+// is registered, and the default is a noop one.
+func setupTracing() (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+
+	return tp, nil
+}
+
+func connectToDB(ctx context.Context, env *bootstrap.Env) *pgxpool.Pool {
+	pool, err := postgres.Connect(ctx, env.DBSource, postgres.PoolConfig{
+		MinConns:          env.DBPoolMinConns,
+		MaxConns:          env.DBPoolMaxConns,
+		HealthCheckPeriod: env.DBPoolHealthCheckPeriod,
+	})
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
+	logrus.Infof("connected to Postgresql")
+
+	return pool
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// runMigrateCommand handles `migrate up|down N|force V|version|drop`,
+// giving operators a recovery path when a migration is left dirty.
+func runMigrateCommand(m *migrate.Migrate, args []string) {
+	if len(args) == 0 {
+		logrus.Fatalf("migrate: missing verb (up|down|force|version|drop)")
+	}
+
+	switch args[0] {
+	case "up":
+		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+			logrus.Fatalf("migrate up: %v", err)
+		}
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			parsed, err := strconv.Atoi(args[1])
+			if err != nil {
+				logrus.Fatalf("migrate down: invalid step count %q: %v", args[1], err)
+			}
+			n = parsed
+		}
+		if err := m.Steps(-n); err != nil && err != migrate.ErrNoChange {
+			logrus.Fatalf("migrate down: %v", err)
+		}
+	case "force":
+		if len(args) < 2 {
+			logrus.Fatalf("migrate force: missing version")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			logrus.Fatalf("migrate force: invalid version %q: %v", args[1], err)
+		}
+		if err := m.Force(version); err != nil {
+			logrus.Fatalf("migrate force: %v", err)
+		}
+	case "version":
+		version, dirty, err := m.Version()
+		if err != nil && err != migrate.ErrNilVersion {
+			logrus.Fatalf("migrate version: %v", err)
+		}
+		logrus.Infof("version=%d dirty=%t", version, dirty)
+	case "drop":
+		if err := m.Drop(); err != nil {
+			logrus.Fatalf("migrate drop: %v", err)
+		}
+	default:
+		logrus.Fatalf("migrate: unknown verb %q", args[0])
+	}
+}
@@ -0,0 +1,73 @@
+// Package handler exposes the picking service over HTTP.
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"app/internal/logging"
+	"app/internal/service/picking"
+	"app/internal/storage/postgres"
+)
+
+// Handler serves the warehouse HTTP API.
+type Handler struct {
+	Store     postgres.Store
+	Pool      *pgxpool.Pool
+	EntryRack string
+}
+
+// New builds a Handler backed by store for reads and pool for the job
+// queue. entryRack is the rack the nearest-neighbour route starts from.
+func New(store postgres.Store, pool *pgxpool.Pool, entryRack string) *Handler {
+	return &Handler{Store: store, Pool: pool, EntryRack: entryRack}
+}
+
+// Routes registers the handler's endpoints on mux.
+func (h *Handler) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /pick-lists", h.PostPickLists)
+	mux.HandleFunc("POST /jobs", h.PostJobs)
+	mux.HandleFunc("GET /jobs/{id}", h.GetJob)
+}
+
+type postPickListsRequest struct {
+	OrderIDs []int `json:"order_ids"`
+}
+
+// PostPickLists computes a pick route for the order IDs in the request
+// body. It returns JSON by default, or the plain-text report when the
+// client sends `Accept: text/plain`.
+func (h *Handler) PostPickLists(w http.ResponseWriter, r *http.Request) {
+	var req postPickListsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.OrderIDs) == 0 {
+		http.Error(w, "order_ids must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx := logging.WithFields(r.Context(), logrus.Fields{"request_id": r.Header.Get("X-Request-Id")})
+
+	plan, err := picking.Solve(ctx, h.Store, req.OrderIDs, h.EntryRack)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("failed to solve pick list: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/plain") {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(picking.RenderText(plan)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
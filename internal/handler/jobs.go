@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"app/internal/logging"
+	"app/internal/worker"
+)
+
+type postJobsRequest struct {
+	OrderIDs []int `json:"order_ids"`
+}
+
+type jobResponse struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Result any    `json:"result,omitempty"`
+}
+
+// PostJobs enqueues an asynchronous pick-list job and returns its ID.
+func (h *Handler) PostJobs(w http.ResponseWriter, r *http.Request) {
+	var req postJobsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.OrderIDs) == 0 {
+		http.Error(w, "order_ids must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	ctx := logging.WithFields(r.Context(), logrus.Fields{"request_id": r.Header.Get("X-Request-Id"), "order_ids": req.OrderIDs})
+
+	id, err := worker.Enqueue(ctx, h.Pool, req.OrderIDs)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("failed to enqueue pick job: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(jobResponse{ID: id, Status: string(worker.StatusPending)})
+}
+
+// GetJob returns the current status (and, once done, the result) of a job.
+func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	ctx := logging.WithFields(r.Context(), logrus.Fields{"request_id": r.Header.Get("X-Request-Id"), "job_id": id})
+
+	job, err := worker.GetJob(ctx, h.Pool, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("failed to get pick job: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if job == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	resp := jobResponse{ID: job.ID, Status: string(job.Status), Error: job.Error}
+	if job.Status == worker.StatusDone && len(job.ResultJSON) > 0 {
+		resp.Result = json.RawMessage(job.ResultJSON)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
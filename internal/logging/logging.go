@@ -0,0 +1,41 @@
+// Package logging attaches request-scoped fields (order_ids, job_id,
+// request_id, ...) to a context.Context so every log line written while
+// handling a request or job carries them, without threading a logger
+// through every function signature.
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ctxKey struct{}
+
+// WithFields returns a context carrying fields merged on top of any
+// fields already attached to ctx.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	merged := logrus.Fields{}
+	if existing, ok := ctx.Value(ctxKey{}).(logrus.Fields); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return context.WithValue(ctx, ctxKey{}, merged)
+}
+
+// FromContext returns a logger entry carrying every field attached to ctx
+// via WithFields. Callers with no attached fields get the bare standard
+// logger.
+func FromContext(ctx context.Context) *logrus.Entry {
+	fields, ok := ctx.Value(ctxKey{}).(logrus.Fields)
+	if !ok {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+
+	return logrus.WithFields(fields)
+}
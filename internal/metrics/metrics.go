@@ -0,0 +1,30 @@
+// Package metrics holds the Prometheus collectors exposed on /metrics.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// OrdersProcessed counts orders included in a computed pick plan.
+	OrdersProcessed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "pick_orders_processed_total",
+		Help: "Total number of orders included in a computed pick plan.",
+	})
+
+	// RacksVisited counts racks included in a computed pick plan.
+	RacksVisited = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "pick_racks_visited_total",
+		Help: "Total number of racks included in a computed pick plan.",
+	})
+
+	// QueryLatency tracks how long the pick-rows query takes, to make
+	// slow warehouses (large joins) attributable.
+	QueryLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pick_query_latency_seconds",
+		Help:    "Latency of the pick-rows query.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(OrdersProcessed, RacksVisited, QueryLatency)
+}
@@ -0,0 +1,252 @@
+// Package picking implements the warehouse pick-list computation: given a
+// set of order IDs it groups the required products by rack and orders the
+// racks into a single walkable route through the warehouse.
+package picking
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+
+	"app/internal/logging"
+	"app/internal/metrics"
+	"app/internal/storage/postgres"
+)
+
+var tracer = otel.Tracer("app/internal/service/picking")
+
+// OrderQuantity is the quantity of a product requested by a single order.
+type OrderQuantity struct {
+	OrderID  int `json:"order_id"`
+	Quantity int `json:"quantity"`
+}
+
+// Item is a product to be picked from a rack, along with every order that
+// needs it and any secondary racks it can also be found on.
+type Item struct {
+	ProductID      int             `json:"product_id"`
+	ProductName    string          `json:"product_name"`
+	Orders         []OrderQuantity `json:"orders"`
+	SecondaryRacks []string        `json:"secondary_racks,omitempty"`
+}
+
+// Rack is a stop on the pick route: a rack name and the items to collect
+// from it.
+type Rack struct {
+	RackName string `json:"rack_name"`
+	Items    []Item `json:"items"`
+}
+
+// PickPlan is the result of Solve: the racks a picker should visit, in
+// walking order.
+type PickPlan struct {
+	Racks []Rack `json:"racks"`
+}
+
+// Solve computes a PickPlan for orderIDs. entryRack is the rack the picker
+// starts from; the returned racks are ordered by a nearest-neighbour walk
+// starting there so the picker sweeps the warehouse once. Solve is safe to
+// call concurrently: it touches no shared state besides store.
+func Solve(ctx context.Context, store postgres.Store, orderIDs []int, entryRack string) (*PickPlan, error) {
+	ctx, span := tracer.Start(ctx, "picking.Solve")
+	defer span.End()
+
+	ctx = logging.WithFields(ctx, logrus.Fields{"order_ids": orderIDs})
+	logger := logging.FromContext(ctx)
+
+	rows, err := getPickRows(ctx, store, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pick rows: %v", err)
+	}
+
+	plan := buildPlanTraced(ctx, rows, entryRack)
+
+	metrics.OrdersProcessed.Add(float64(len(orderIDs)))
+	metrics.RacksVisited.Add(float64(len(plan.Racks)))
+	logger.WithField("racks_visited", len(plan.Racks)).Info("computed pick plan")
+
+	return plan, nil
+}
+
+// getPickRows wraps the store call in its own span and reports query
+// latency, so slow warehouses (large joins) are attributable.
+func getPickRows(ctx context.Context, store postgres.Store, orderIDs []int) ([]postgres.PickRow, error) {
+	ctx, span := tracer.Start(ctx, "picking.GetPickRows")
+	defer span.End()
+
+	start := time.Now()
+	rows, err := store.GetPickRows(ctx, orderIDs)
+	metrics.QueryLatency.Observe(time.Since(start).Seconds())
+
+	return rows, err
+}
+
+// buildPlanTraced wraps buildPlan in its own span.
+func buildPlanTraced(ctx context.Context, rows []postgres.PickRow, entryRack string) *PickPlan {
+	_, span := tracer.Start(ctx, "picking.buildPlan")
+	defer span.End()
+
+	return buildPlan(rows, entryRack)
+}
+
+type rackRef struct {
+	name   string
+	isMain bool
+}
+
+// buildPlan assembles a PickPlan from the joined (order, product, rack)
+// rows GetPickRows returns. Because the join fans out across both orders
+// and racks for the same product, quantities and rack assignments are
+// deduplicated per product before the plan is built.
+func buildPlan(rows []postgres.PickRow, entryRack string) *PickPlan {
+	productNames := make(map[int]string)
+	ordersByProduct := make(map[int]map[int]int)    // productID -> orderID -> quantity
+	racksByProduct := make(map[int]map[int]rackRef) // productID -> rackID -> rackRef
+
+	for _, row := range rows {
+		productNames[row.ProductID] = row.ProductName
+
+		if ordersByProduct[row.ProductID] == nil {
+			ordersByProduct[row.ProductID] = make(map[int]int)
+		}
+		ordersByProduct[row.ProductID][row.OrderID] = row.Quantity
+
+		// row.RackID is nil for a product with no product_rack assignment;
+		// it still needs an Item, just with no rack to group it under, so
+		// leave racksByProduct empty for it rather than skipping the row.
+		if row.RackID == nil {
+			continue
+		}
+
+		if racksByProduct[row.ProductID] == nil {
+			racksByProduct[row.ProductID] = make(map[int]rackRef)
+		}
+
+		var rackName string
+		if row.RackName != nil {
+			rackName = *row.RackName
+		}
+		var isMain bool
+		if row.IsMain != nil {
+			isMain = *row.IsMain
+		}
+		racksByProduct[row.ProductID][*row.RackID] = rackRef{name: rackName, isMain: isMain}
+	}
+
+	rackItems := make(map[string][]Item)
+	for productID, orderQuantities := range ordersByProduct {
+		var mainRackName string
+		secondary := make([]string, 0)
+		for _, ref := range racksByProduct[productID] {
+			if ref.isMain {
+				mainRackName = ref.name
+			} else {
+				secondary = append(secondary, ref.name)
+			}
+		}
+		sort.Strings(secondary)
+
+		orders := make([]OrderQuantity, 0, len(orderQuantities))
+		for orderID, quantity := range orderQuantities {
+			orders = append(orders, OrderQuantity{OrderID: orderID, Quantity: quantity})
+		}
+		sort.Slice(orders, func(i, j int) bool { return orders[i].OrderID < orders[j].OrderID })
+
+		rackItems[mainRackName] = append(rackItems[mainRackName], Item{
+			ProductID:      productID,
+			ProductName:    productNames[productID],
+			Orders:         orders,
+			SecondaryRacks: secondary,
+		})
+	}
+
+	visitedRacks := make([]string, 0, len(rackItems))
+	for rackName := range rackItems {
+		visitedRacks = append(visitedRacks, rackName)
+	}
+
+	route := nearestNeighbourRoute(visitedRacks, entryRack)
+
+	plan := &PickPlan{Racks: make([]Rack, 0, len(route))}
+	for _, rackName := range route {
+		items := rackItems[rackName]
+		sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+		plan.Racks = append(plan.Racks, Rack{RackName: rackName, Items: items})
+	}
+
+	return plan
+}
+
+// nearestNeighbourRoute orders racks into a walkable route: starting from
+// entryRack, it repeatedly hops to the unvisited rack closest to the
+// current one.
+func nearestNeighbourRoute(racks []string, entryRack string) []string {
+	remaining := make([]string, len(racks))
+	copy(remaining, racks)
+	sort.Strings(remaining)
+
+	route := make([]string, 0, len(remaining))
+	current := entryRack
+	for len(remaining) > 0 {
+		nextIdx := 0
+		nextDist := rackDistance(current, remaining[0])
+		for i := 1; i < len(remaining); i++ {
+			if d := rackDistance(current, remaining[i]); d < nextDist {
+				nextIdx, nextDist = i, d
+			}
+		}
+
+		current = remaining[nextIdx]
+		route = append(route, current)
+		remaining = append(remaining[:nextIdx], remaining[nextIdx+1:]...)
+	}
+
+	return route
+}
+
+var rackCoordinateRe = regexp.MustCompile(`\d+`)
+
+// rackDistance approximates the physical distance between two racks from
+// the numeric coordinate embedded in their names (e.g. "A12" -> 12),
+// falling back to lexicographic distance when a rack has no such coordinate.
+func rackDistance(a, b string) int {
+	ca, okA := rackCoordinate(a)
+	cb, okB := rackCoordinate(b)
+	if okA && okB {
+		d := ca - cb
+		if d < 0 {
+			d = -d
+		}
+		return d
+	}
+
+	return lexicalDistance(a, b)
+}
+
+func rackCoordinate(rackName string) (int, bool) {
+	match := rackCoordinateRe.FindString(rackName)
+	if match == "" {
+		return 0, false
+	}
+
+	n := 0
+	for _, r := range match {
+		n = n*10 + int(r-'0')
+	}
+	return n, true
+}
+
+func lexicalDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a < b {
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,81 @@
+package picking
+
+import (
+	"context"
+	"testing"
+
+	"app/internal/storage/postgres"
+)
+
+type fakeStore struct {
+	rows []postgres.PickRow
+}
+
+func (f *fakeStore) GetPickRows(ctx context.Context, orderIDs []int) ([]postgres.PickRow, error) {
+	return f.rows, nil
+}
+
+func intp(i int) *int       { return &i }
+func strp(s string) *string { return &s }
+func boolp(b bool) *bool    { return &b }
+
+func TestSolveOrdersRacksAlongRoute(t *testing.T) {
+	store := &fakeStore{
+		rows: []postgres.PickRow{
+			{OrderID: 1, ProductID: 10, Quantity: 2, ProductName: "widget", RackID: intp(1), RackName: strp("A1"), IsMain: boolp(true)},
+			{OrderID: 1, ProductID: 20, Quantity: 1, ProductName: "gadget", RackID: intp(2), RackName: strp("A5"), IsMain: boolp(true)},
+			{OrderID: 1, ProductID: 20, Quantity: 1, ProductName: "gadget", RackID: intp(3), RackName: strp("A9"), IsMain: boolp(false)},
+		},
+	}
+
+	plan, err := Solve(context.Background(), store, []int{1}, "A1")
+	if err != nil {
+		t.Fatalf("Solve returned error: %v", err)
+	}
+
+	if len(plan.Racks) != 2 {
+		t.Fatalf("expected 2 racks in plan, got %d", len(plan.Racks))
+	}
+	if plan.Racks[0].RackName != "A1" {
+		t.Errorf("expected route to start at A1, got %s", plan.Racks[0].RackName)
+	}
+	if plan.Racks[1].RackName != "A5" {
+		t.Errorf("expected second stop to be A5, got %s", plan.Racks[1].RackName)
+	}
+
+	secondStopItem := plan.Racks[1].Items[0]
+	if len(secondStopItem.SecondaryRacks) != 1 || secondStopItem.SecondaryRacks[0] != "A9" {
+		t.Errorf("expected secondary rack A9 for gadget, got %v", secondStopItem.SecondaryRacks)
+	}
+}
+
+func TestSolveProductWithoutRackIsStillListed(t *testing.T) {
+	store := &fakeStore{
+		rows: []postgres.PickRow{
+			{OrderID: 1, ProductID: 10, Quantity: 2, ProductName: "widget", RackID: intp(1), RackName: strp("A1"), IsMain: boolp(true)},
+			{OrderID: 1, ProductID: 30, Quantity: 1, ProductName: "unplaced"},
+		},
+	}
+
+	plan, err := Solve(context.Background(), store, []int{1}, "A1")
+	if err != nil {
+		t.Fatalf("Solve returned error: %v", err)
+	}
+
+	if len(plan.Racks) != 2 {
+		t.Fatalf("expected 2 racks in plan, got %d", len(plan.Racks))
+	}
+
+	var unplacedRack *Rack
+	for i, rack := range plan.Racks {
+		if rack.RackName == "" {
+			unplacedRack = &plan.Racks[i]
+		}
+	}
+	if unplacedRack == nil {
+		t.Fatalf("expected a rack with an empty name for the unassigned product, got %v", plan.Racks)
+	}
+	if len(unplacedRack.Items) != 1 || unplacedRack.Items[0].ProductID != 30 {
+		t.Errorf("expected unplaced product 30 to be listed, got %v", unplacedRack.Items)
+	}
+}
@@ -0,0 +1,33 @@
+package picking
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderText renders a PickPlan as the CLI's human-readable report: one
+// section per rack, in route order, with each product listed once and
+// followed by one line per order that needs it.
+func RenderText(plan *PickPlan) string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "=+=+=+=")
+	for _, rack := range plan.Racks {
+		fmt.Fprintf(&b, "===Стеллаж %s\n", rack.RackName)
+
+		for _, item := range rack.Items {
+			fmt.Fprintf(&b, "%s (id=%d)\n", item.ProductName, item.ProductID)
+			for _, order := range item.Orders {
+				fmt.Fprintf(&b, "заказ %d, %d шт\n", order.OrderID, order.Quantity)
+			}
+
+			if len(item.SecondaryRacks) > 0 {
+				fmt.Fprintf(&b, "доп стеллаж: %s\n", strings.Join(item.SecondaryRacks, ","))
+			}
+
+			fmt.Fprintln(&b)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package picking
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"app/internal/storage/postgres"
+)
+
+// simulatedRoundTripLatency stands in for a single DB round trip so the
+// benchmark below reflects network cost, not just in-process CPU time.
+const simulatedRoundTripLatency = time.Millisecond
+
+// generatePickRows builds rows for nOrders orders, each needing one
+// product stocked on one rack, mirroring the shape Solve consumes.
+func generatePickRows(nOrders int) []postgres.PickRow {
+	rows := make([]postgres.PickRow, 0, nOrders)
+	for i := 0; i < nOrders; i++ {
+		rows = append(rows, postgres.PickRow{
+			OrderID:     i,
+			ProductID:   i,
+			Quantity:    1,
+			ProductName: fmt.Sprintf("product-%d", i),
+			RackID:      intp(i % 50),
+			RackName:    strp(fmt.Sprintf("A%d", i%50)),
+			IsMain:      boolp(true),
+		})
+	}
+	return rows
+}
+
+// roundTripStore simulates a store backed by roundTrips sequential DB round
+// trips before returning rows, so BenchmarkSolve can compare Solve's actual
+// cost under a single-query store against a chattier one.
+type roundTripStore struct {
+	rows       []postgres.PickRow
+	roundTrips int
+}
+
+func (s *roundTripStore) GetPickRows(ctx context.Context, orderIDs []int) ([]postgres.PickRow, error) {
+	for i := 0; i < s.roundTrips; i++ {
+		time.Sleep(simulatedRoundTripLatency)
+	}
+	return s.rows, nil
+}
+
+// BenchmarkSolve compares Solve's cost against a store making a single round
+// trip (today's GetPickRows) with one making four (the shape of the old
+// getOrders/initProductsNames/initRacks/initRacksNames pipeline), at the
+// order-set sizes called out when the N+1 queries were eliminated.
+func BenchmarkSolve(b *testing.B) {
+	ctx := context.Background()
+
+	for _, n := range []int{1, 10, 100, 1000} {
+		rows := generatePickRows(n)
+		orderIDs := make([]int, n)
+		for i := range orderIDs {
+			orderIDs[i] = i
+		}
+
+		b.Run(fmt.Sprintf("four-round-trips/orders=%d", n), func(b *testing.B) {
+			store := &roundTripStore{rows: rows, roundTrips: 4}
+			for i := 0; i < b.N; i++ {
+				if _, err := Solve(ctx, store, orderIDs, "A0"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("one-round-trip/orders=%d", n), func(b *testing.B) {
+			store := &roundTripStore{rows: rows, roundTrips: 1}
+			for i := 0; i < b.N; i++ {
+				if _, err := Solve(ctx, store, orderIDs, "A0"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
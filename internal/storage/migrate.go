@@ -0,0 +1,35 @@
+// Package storage wires the embedded migrations to a *migrate.Migrate
+// instance; internal/storage/postgres holds the query layer.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"app/internal/storage/migrations"
+)
+
+// NewMigrate builds a *migrate.Migrate over the embedded migration files
+// and db. dbName is the migrate database driver name (e.g. "pgx/v5").
+func NewMigrate(db *sql.DB, dbName string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded migrations: %v", err)
+	}
+
+	driver, err := pgxmigrate.WithInstance(db, &pgxmigrate.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration driver: %v", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, dbName, driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %v", err)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,13 @@
+// Package migrations embeds the SQL migration files so the binary is
+// self-contained and does not depend on an external migrations path.
+//
+// 000001_add_base_schema keeps the version number the old externally
+// hosted migrations used for the base schema, so a database migrated by
+// that system reports version 1 already applied and schema_migrations
+// continues cleanly from 000002 rather than landing in a dirty state.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,80 @@
+// Package postgres is the storage layer for pick-list reads: it owns the
+// queries Solve needs and hides them behind the Store interface, so the
+// picking service never touches *pgxpool.Pool directly. The pick_jobs
+// queue in internal/worker issues its own queries on the pool.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("app/internal/storage/postgres")
+
+// PoolConfig configures the underlying pgx connection pool.
+type PoolConfig struct {
+	MinConns          int32
+	MaxConns          int32
+	HealthCheckPeriod time.Duration
+}
+
+// Connect opens a pgx connection pool to dsn and verifies it with a ping.
+func Connect(ctx context.Context, dsn string, cfg PoolConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pool config: %v", err)
+	}
+
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+	poolCfg.ConnConfig.Tracer = &queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Postgresql: %v", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to Postgresql: %v", err)
+	}
+
+	return pool, nil
+}
+
+type queryTracerCtxKey struct{}
+
+// queryTracer attaches an OTel span to every query the pool issues, so a
+// slow warehouse query is attributable to the SQL that caused it. It
+// implements pgx.QueryTracer directly (the claircore/pgxpool hook
+// pattern) rather than pulling in a separate otelpgx dependency.
+type queryTracer struct{}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := tracer.Start(ctx, "pgx.Query", trace.WithAttributes(attribute.String("db.statement", data.SQL)))
+	return context.WithValue(ctx, queryTracerCtxKey{}, span)
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(queryTracerCtxKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		span.RecordError(data.Err)
+	}
+	span.End()
+}
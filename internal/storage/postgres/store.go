@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PickRow is one (order, product, rack) combination needed to fulfil a
+// pick-list request. GetPickRows returns every rack a requested product
+// can be picked from, so a product with secondary racks appears once per
+// rack. RackID, RackName and IsMain are nil when the product has no
+// product_rack assignment at all, so such products still come back
+// instead of being silently dropped from the pick list.
+type PickRow struct {
+	OrderID     int
+	ProductID   int
+	Quantity    int
+	ProductName string
+	RackID      *int
+	RackName    *string
+	IsMain      *bool
+}
+
+// Store is the read access the picking service needs. It is implemented
+// by store below and can be faked in tests.
+type Store interface {
+	GetPickRows(ctx context.Context, orderIDs []int) ([]PickRow, error)
+}
+
+// store is the Postgres-backed Store implementation.
+type store struct {
+	pool *pgxpool.Pool
+}
+
+// NewStore builds a Store backed by pool.
+func NewStore(pool *pgxpool.Pool) Store {
+	return &store{pool: pool}
+}
+
+// GetPickRows joins orders, products and racks in a single round trip so
+// Solve no longer needs four sequential queries. product_rack and rack are
+// LEFT JOINed: a product with no rack assignment still comes back, with
+// RackID, RackName and IsMain left nil, instead of being dropped from the
+// result entirely.
+func (s *store) GetPickRows(ctx context.Context, orderIDs []int) ([]PickRow, error) {
+	const q = `
+WITH target_orders AS (
+	SELECT o.order_id, o.product_id, o.quantity
+	FROM order_product o
+	WHERE o.order_id = ANY($1)
+)
+SELECT t.order_id, t.product_id, t.quantity, p.product_name, pr.rack_id, r.rack_name, pr.is_main
+FROM target_orders t
+JOIN product p ON p.product_id = t.product_id
+LEFT JOIN product_rack pr ON pr.product_id = t.product_id
+LEFT JOIN rack r ON r.rack_id = pr.rack_id
+`
+	rows, err := s.pool.Query(ctx, q, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pick rows: %v", err)
+	}
+	defer rows.Close()
+
+	var pickRows []PickRow
+	for rows.Next() {
+		var r PickRow
+		err := rows.Scan(&r.OrderID, &r.ProductID, &r.Quantity, &r.ProductName, &r.RackID, &r.RackName, &r.IsMain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pick row: %v", err)
+		}
+		pickRows = append(pickRows, r)
+	}
+
+	return pickRows, rows.Err()
+}
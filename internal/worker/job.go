@@ -0,0 +1,78 @@
+// Package worker decouples pick-list requests from their computation: jobs
+// are enqueued in the pick_jobs table and picked up by a pool of worker
+// goroutines so bulk requests don't block on the caller's HTTP round trip.
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Status is the lifecycle state of a pick job.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job is a row of the pick_jobs table.
+type Job struct {
+	ID         int64
+	OrderIDs   []int
+	Status     Status
+	ResultJSON []byte
+	ResultText string
+	Error      string
+}
+
+// Enqueue inserts a new pending job for orderIDs and returns its ID.
+func Enqueue(ctx context.Context, pool *pgxpool.Pool, orderIDs []int) (int64, error) {
+	const q = `
+INSERT INTO pick_jobs (order_ids, status)
+VALUES ($1, $2)
+RETURNING id
+`
+	var id int64
+	if err := pool.QueryRow(ctx, q, orderIDs, StatusPending).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to enqueue pick job: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetJob loads a job by ID. It returns (nil, nil) if no such job exists.
+func GetJob(ctx context.Context, pool *pgxpool.Pool, id int64) (*Job, error) {
+	const q = `
+SELECT id, order_ids, status, result_json, result_text, error
+FROM pick_jobs
+WHERE id = $1
+`
+	var job Job
+	var resultJSON, resultText, jobErr *string
+	row := pool.QueryRow(ctx, q, id)
+	err := row.Scan(&job.ID, &job.OrderIDs, &job.Status, &resultJSON, &resultText, &jobErr)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pick job: %v", err)
+	}
+
+	if resultJSON != nil {
+		job.ResultJSON = []byte(*resultJSON)
+	}
+	if resultText != nil {
+		job.ResultText = *resultText
+	}
+	if jobErr != nil {
+		job.Error = *jobErr
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"app/internal/logging"
+	"app/internal/service/picking"
+	"app/internal/storage/postgres"
+)
+
+var errNoJob = errors.New("no pending job")
+
+// defaultStaleAfter is how long a job may sit in "running" before a
+// worker is assumed to have died mid-computation and the job is reclaimed.
+const defaultStaleAfter = 5 * time.Minute
+
+// Pool runs a fixed number of worker goroutines pulling pending jobs off
+// the pick_jobs table.
+type Pool struct {
+	Pool         *pgxpool.Pool
+	Store        postgres.Store
+	EntryRack    string
+	Concurrency  int
+	PollInterval time.Duration
+	StaleAfter   time.Duration
+}
+
+// NewPool builds a Pool. If pollInterval is zero it defaults to one
+// second; if staleAfter is zero it defaults to five minutes.
+func NewPool(pool *pgxpool.Pool, store postgres.Store, entryRack string, concurrency int, pollInterval, staleAfter time.Duration) *Pool {
+	if pollInterval == 0 {
+		pollInterval = time.Second
+	}
+	if staleAfter == 0 {
+		staleAfter = defaultStaleAfter
+	}
+
+	return &Pool{
+		Pool:         pool,
+		Store:        store,
+		EntryRack:    entryRack,
+		Concurrency:  concurrency,
+		PollInterval: pollInterval,
+		StaleAfter:   staleAfter,
+	}
+}
+
+// Run starts Concurrency worker goroutines. It returns immediately; the
+// workers stop once ctx is cancelled.
+func (p *Pool) Run(ctx context.Context) {
+	for i := 0; i < p.Concurrency; i++ {
+		go p.runWorker(ctx)
+	}
+}
+
+func (p *Pool) runWorker(ctx context.Context) {
+	ticker := time.NewTicker(p.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.processOne(ctx); err != nil && !errors.Is(err, errNoJob) {
+				logrus.Errorf("pick job worker: %v", err)
+			}
+		}
+	}
+}
+
+// processOne claims the oldest pending (or stale running) job, if any,
+// and runs it to completion. It returns errNoJob when there is nothing to
+// do.
+func (p *Pool) processOne(ctx context.Context) error {
+	id, orderIDs, err := p.claimJob(ctx, time.Now().Add(-p.StaleAfter))
+	if err != nil {
+		return err
+	}
+
+	ctx = logging.WithFields(ctx, logrus.Fields{"job_id": id, "order_ids": orderIDs})
+	logging.FromContext(ctx).Info("running pick job")
+
+	plan, err := picking.Solve(ctx, p.Store, orderIDs, p.EntryRack)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("pick job failed: %v", err)
+		return p.markFailed(ctx, id, err)
+	}
+
+	resultJSON, err := json.Marshal(plan)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("pick job failed: %v", err)
+		return p.markFailed(ctx, id, err)
+	}
+
+	return p.markDone(ctx, id, resultJSON, picking.RenderText(plan))
+}
+
+// claimJob picks the job with the lowest id that is either pending or
+// "running" with an updated_at older than staleBefore — a worker that
+// died mid-computation leaves its job running forever otherwise. Pending
+// jobs are not preferred over stale running ones.
+func (p *Pool) claimJob(ctx context.Context, staleBefore time.Time) (id int64, orderIDs []int, err error) {
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	const selectQ = `
+SELECT id, order_ids
+FROM pick_jobs
+WHERE status = $1
+   OR (status = $2 AND updated_at < $3)
+ORDER BY id
+FOR UPDATE SKIP LOCKED
+LIMIT 1
+`
+	row := tx.QueryRow(ctx, selectQ, StatusPending, StatusRunning, staleBefore)
+	if err := row.Scan(&id, &orderIDs); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil, errNoJob
+		}
+		return 0, nil, err
+	}
+
+	const updateQ = `UPDATE pick_jobs SET status = $1, updated_at = now() WHERE id = $2`
+	if _, err := tx.Exec(ctx, updateQ, StatusRunning, id); err != nil {
+		return 0, nil, err
+	}
+
+	return id, orderIDs, tx.Commit(ctx)
+}
+
+func (p *Pool) markDone(ctx context.Context, id int64, resultJSON []byte, resultText string) error {
+	const q = `
+UPDATE pick_jobs
+SET status = $1, result_json = $2, result_text = $3, updated_at = now()
+WHERE id = $4
+`
+	_, err := p.Pool.Exec(ctx, q, StatusDone, resultJSON, resultText, id)
+	return err
+}
+
+func (p *Pool) markFailed(ctx context.Context, id int64, jobErr error) error {
+	const q = `
+UPDATE pick_jobs
+SET status = $1, error = $2, updated_at = now()
+WHERE id = $3
+`
+	_, err := p.Pool.Exec(ctx, q, StatusFailed, jobErr.Error(), id)
+	return err
+}